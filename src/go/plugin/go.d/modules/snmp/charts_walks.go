@@ -0,0 +1,106 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package snmp
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/netdata/netdata/go/plugins/plugin/go.d/agent/module"
+)
+
+// updateWalkCharts diffs w.rows against the freshly discovered rows and
+// adds/removes charts for rows that appeared/disappeared.
+func (s *SNMP) updateWalkCharts(w *walkState, rows map[string]string) {
+	for idx, label := range rows {
+		if _, ok := w.rows[idx]; !ok {
+			s.addWalkRowChart(w.rule, label)
+		}
+	}
+	for idx, label := range w.rows {
+		if _, ok := rows[idx]; !ok {
+			s.removeWalkRowChart(w.rule, label)
+		}
+	}
+}
+
+// walkChartTemplates returns the per-row chart templates configured for
+// rule, or a single reasonable default when the rule sets none.
+func walkChartTemplates(rule WalkRule) []WalkChart {
+	if len(rule.Charts) > 0 {
+		return rule.Charts
+	}
+	return []WalkChart{{Units: "value"}}
+}
+
+func (s *SNMP) addWalkRowChart(rule WalkRule, label string) {
+	for _, tmpl := range walkChartTemplates(rule) {
+		chart := &module.Chart{
+			ID:    walkChartID(rule.Name, tmpl.IDSuffix, label),
+			Title: walkChartTitle(tmpl.Title, rule.Name, label),
+			Units: tmpl.Units,
+			Fam:   rule.Name,
+			Ctx:   "snmp." + rule.Name,
+			Type:  module.ChartType(tmpl.Type),
+		}
+		if chart.Units == "" {
+			chart.Units = "value"
+		}
+
+		valueOIDs := tmpl.ValueOIDs
+		if len(valueOIDs) == 0 {
+			valueOIDs = rule.ValueOIDs
+		}
+
+		for _, valueOID := range valueOIDs {
+			name := valueOID
+			if i := strings.LastIndexByte(valueOID, '.'); i != -1 {
+				name = valueOID[i+1:]
+			}
+			_ = chart.AddDim(&module.Dim{
+				ID:   walkMetricID(rule.Name, valueOID, label),
+				Name: name,
+				Algo: module.Incremental,
+			})
+		}
+
+		if err := s.charts.Add(chart); err != nil {
+			s.Warning(err)
+		}
+	}
+}
+
+func (s *SNMP) removeWalkRowChart(rule WalkRule, label string) {
+	for _, tmpl := range walkChartTemplates(rule) {
+		id := walkChartID(rule.Name, tmpl.IDSuffix, label)
+		if chart := s.charts.Get(id); chart != nil {
+			chart.MarkRemove()
+			chart.MarkNotCreated()
+		}
+	}
+}
+
+// walkChartTitle renders a chart template's title for a discovered row.
+// A "%s" placeholder in the template is substituted with label; otherwise
+// label is appended, so every row still gets a distinct title even when the
+// template doesn't define one.
+func walkChartTitle(title, ruleName, label string) string {
+	switch {
+	case title == "":
+		return fmt.Sprintf("%s %s", ruleName, label)
+	case strings.Contains(title, "%s"):
+		return fmt.Sprintf(title, label)
+	default:
+		return fmt.Sprintf("%s %s", title, label)
+	}
+}
+
+func walkChartID(ruleName, idSuffix, label string) string {
+	r := strings.NewReplacer(" ", "_", ".", "_", "/", "_")
+
+	id := "snmp_walk_" + r.Replace(ruleName)
+	if idSuffix != "" {
+		id += "_" + r.Replace(idSuffix)
+	}
+	return id + "_" + r.Replace(label)
+}
@@ -0,0 +1,84 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package snmp
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_walkChartTitle(t *testing.T) {
+	tests := map[string]struct {
+		title, ruleName, label, want string
+	}{
+		"no template title":   {title: "", ruleName: "if", label: "eth0", want: "if eth0"},
+		"template without %s": {title: "Interface Traffic", ruleName: "if", label: "eth0", want: "Interface Traffic eth0"},
+		"template with %s":    {title: "Interface %s Traffic", ruleName: "if", label: "eth0", want: "Interface eth0 Traffic"},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, test.want, walkChartTitle(test.title, test.ruleName, test.label))
+		})
+	}
+}
+
+func Test_walkChartID_usesIDSuffix(t *testing.T) {
+	withSuffix := walkChartID("if", "traffic", "eth0")
+	withoutSuffix := walkChartID("if", "", "eth0")
+
+	assert.NotEqual(t, withSuffix, withoutSuffix)
+	assert.Contains(t, withSuffix, "traffic")
+}
+
+func TestSNMP_addWalkRowChart_perRuleCharts(t *testing.T) {
+	s := New()
+	rule := WalkRule{
+		Name:      "if",
+		RootOID:   ".1.3.6.1.2.1.2.2.1.1",
+		ValueOIDs: []string{".1.3.6.1.2.1.31.1.1.1.6", ".1.3.6.1.2.1.31.1.1.1.10"},
+		Charts: []WalkChart{
+			{IDSuffix: "traffic", Title: "Interface %s Traffic", Units: "bytes/s"},
+		},
+	}
+
+	s.addWalkRowChart(rule, "eth0")
+	s.addWalkRowChart(rule, "eth1")
+
+	chartEth0 := s.charts.Get(walkChartID("if", "traffic", "eth0"))
+	chartEth1 := s.charts.Get(walkChartID("if", "traffic", "eth1"))
+
+	require.NotNil(t, chartEth0)
+	require.NotNil(t, chartEth1)
+	assert.Equal(t, "Interface eth0 Traffic", chartEth0.Title)
+	assert.Equal(t, "Interface eth1 Traffic", chartEth1.Title)
+	assert.NotEqual(t, chartEth0.Title, chartEth1.Title)
+	assert.Len(t, chartEth0.Dims, 2)
+}
+
+func TestSNMP_addWalkRowChart_perChartValueOIDs(t *testing.T) {
+	s := New()
+	rule := WalkRule{
+		Name:      "if",
+		RootOID:   ".1.3.6.1.2.1.2.2.1.1",
+		ValueOIDs: []string{".1.3.6.1.2.1.31.1.1.1.6", ".1.3.6.1.2.1.31.1.1.1.10", ".1.3.6.1.2.1.2.2.1.14"},
+		Charts: []WalkChart{
+			{IDSuffix: "traffic", Title: "Interface %s Traffic", Units: "bytes/s",
+				ValueOIDs: []string{".1.3.6.1.2.1.31.1.1.1.6", ".1.3.6.1.2.1.31.1.1.1.10"}},
+			{IDSuffix: "errors", Title: "Interface %s Errors", Units: "errors/s",
+				ValueOIDs: []string{".1.3.6.1.2.1.2.2.1.14"}},
+		},
+	}
+
+	s.addWalkRowChart(rule, "eth0")
+
+	traffic := s.charts.Get(walkChartID("if", "traffic", "eth0"))
+	errors := s.charts.Get(walkChartID("if", "errors", "eth0"))
+
+	require.NotNil(t, traffic)
+	require.NotNil(t, errors)
+	assert.Len(t, traffic.Dims, 2)
+	assert.Len(t, errors.Dims, 1)
+}
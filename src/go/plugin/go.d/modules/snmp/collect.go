@@ -0,0 +1,21 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package snmp
+
+func (s *SNMP) collect() (map[string]int64, error) {
+	mx := make(map[string]int64)
+
+	if len(s.customOids) > 0 {
+		if err := s.collectOIDs(mx); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(s.walks) > 0 {
+		if err := s.collectWalks(mx); err != nil {
+			return nil, err
+		}
+	}
+
+	return mx, nil
+}
@@ -0,0 +1,50 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package snmp
+
+import (
+	"github.com/gosnmp/gosnmp"
+)
+
+// getOIDsChunked issues Get calls for oids in chunks of Options.MaxOIDs and
+// returns the collected values keyed by OID. It is shared by plain OID
+// collection and by per-index value collection of table walks.
+func (s *SNMP) getOIDsChunked(oids []string) (map[string]int64, error) {
+	values := make(map[string]int64)
+
+	for i, end := 0, 0; i < len(oids); i += s.Options.MaxOIDs {
+		if end = i + s.Options.MaxOIDs; end > len(oids) {
+			end = len(oids)
+		}
+
+		chunk := oids[i:end]
+		resp, err := s.snmpClient.Get(chunk)
+		if err != nil {
+			s.Errorf("cannot get SNMP data: %v", err)
+			return nil, err
+		}
+
+		for i, oid := range chunk {
+			if i >= len(resp.Variables) {
+				continue
+			}
+
+			switch v := resp.Variables[i]; v.Type {
+			case gosnmp.Boolean,
+				gosnmp.Counter32,
+				gosnmp.Counter64,
+				gosnmp.Gauge32,
+				gosnmp.TimeTicks,
+				gosnmp.Uinteger32,
+				gosnmp.OpaqueFloat,
+				gosnmp.OpaqueDouble,
+				gosnmp.Integer:
+				values[oid] = gosnmp.ToBigInt(v.Value).Int64()
+			default:
+				s.Debugf("skipping OID '%s' (unsupported type '%s')", oid, v.Type)
+			}
+		}
+	}
+
+	return values, nil
+}
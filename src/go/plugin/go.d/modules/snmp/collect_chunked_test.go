@@ -0,0 +1,78 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package snmp
+
+import (
+	"testing"
+
+	"github.com/gosnmp/gosnmp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// mockSNMPHandler is a minimal snmpHandler backed by an in-memory OID table,
+// used to validate chunked Get batching and table-walk discovery without a
+// real SNMP agent.
+type mockSNMPHandler struct {
+	values map[string]int64
+	// walks maps a root/index OID to the PDUs BulkWalkAll/WalkAll for it
+	// should return.
+	walks map[string][]gosnmp.SnmpPDU
+
+	getCalls      int
+	maxOIDsPerGet int
+}
+
+func (m *mockSNMPHandler) Connect() error { return nil }
+func (m *mockSNMPHandler) Close() error   { return nil }
+
+func (m *mockSNMPHandler) Get(oids []string) (*gosnmp.SnmpPacket, error) {
+	m.getCalls++
+	if len(oids) > m.maxOIDsPerGet {
+		m.maxOIDsPerGet = len(oids)
+	}
+
+	pkt := &gosnmp.SnmpPacket{}
+	for _, oid := range oids {
+		v, ok := m.values[oid]
+		if !ok {
+			continue
+		}
+		pkt.Variables = append(pkt.Variables, gosnmp.SnmpPDU{
+			Name:  oid,
+			Type:  gosnmp.Integer,
+			Value: int(v),
+		})
+	}
+	return pkt, nil
+}
+
+func (m *mockSNMPHandler) Walk(_ string, _ gosnmp.WalkFunc) error     { return nil }
+func (m *mockSNMPHandler) BulkWalk(_ string, _ gosnmp.WalkFunc) error { return nil }
+func (m *mockSNMPHandler) WalkAll(oid string) ([]gosnmp.SnmpPDU, error) {
+	return m.walks[oid], nil
+}
+func (m *mockSNMPHandler) BulkWalkAll(oid string) ([]gosnmp.SnmpPDU, error) {
+	return m.walks[oid], nil
+}
+
+func TestSNMP_getOIDsChunked_batchesByMaxOIDs(t *testing.T) {
+	s := New()
+	s.Options.MaxOIDs = 3
+
+	mock := &mockSNMPHandler{values: map[string]int64{}}
+	var oids []string
+	for i := 0; i < 10; i++ {
+		oid := ".1.3.6.1.2.1.1.0." + string(rune('0'+i))
+		oids = append(oids, oid)
+		mock.values[oid] = int64(i)
+	}
+	s.snmpClient = mock
+
+	values, err := s.getOIDsChunked(oids)
+
+	require.NoError(t, err)
+	assert.Len(t, values, 10)
+	assert.LessOrEqual(t, mock.maxOIDsPerGet, 3)
+	assert.Equal(t, 4, mock.getCalls) // ceil(10/3)
+}
@@ -0,0 +1,139 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package snmp
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gosnmp/gosnmp"
+)
+
+// walkState tracks the discovered rows of a single WalkRule between polls.
+type walkState struct {
+	rule WalkRule
+
+	lastDiscovery time.Time
+	// rows maps a discovered row index (e.g. an ifIndex) to its label
+	// (e.g. the matching ifDescr), or to itself when the rule has no
+	// IndexOIDs to resolve a friendlier name.
+	rows map[string]string
+}
+
+func (s *SNMP) initWalks() {
+	s.walks = s.walks[:0]
+	for _, rule := range s.Walks {
+		s.walks = append(s.walks, &walkState{rule: rule, rows: make(map[string]string)})
+	}
+}
+
+func (s *SNMP) collectWalks(mx map[string]int64) error {
+	for _, w := range s.walks {
+		if err := s.collectWalk(mx, w); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *SNMP) collectWalk(mx map[string]int64, w *walkState) error {
+	if w.lastDiscovery.IsZero() || time.Since(w.lastDiscovery) >= s.walkDiscoveryInterval() {
+		rows, err := s.discoverWalkRows(w.rule)
+		if err != nil {
+			return fmt.Errorf("discovering rows for walk '%s': %v", w.rule.Name, err)
+		}
+		s.updateWalkCharts(w, rows)
+		w.rows = rows
+		w.lastDiscovery = time.Now()
+	}
+
+	if len(w.rows) == 0 {
+		return nil
+	}
+
+	var oids []string
+	for idx := range w.rows {
+		for _, valueOID := range w.rule.ValueOIDs {
+			oids = append(oids, valueOID+"."+idx)
+		}
+	}
+
+	values, err := s.getOIDsChunked(oids)
+	if err != nil {
+		return err
+	}
+
+	for idx, label := range w.rows {
+		for _, valueOID := range w.rule.ValueOIDs {
+			v, ok := values[valueOID+"."+idx]
+			if !ok {
+				continue
+			}
+			mx[walkMetricID(w.rule.Name, valueOID, label)] = v
+		}
+	}
+
+	return nil
+}
+
+// discoverWalkRows bulk-walks RootOID to enumerate row indexes, and
+// IndexOIDs (if any) to resolve a label for each of them.
+func (s *SNMP) discoverWalkRows(rule WalkRule) (map[string]string, error) {
+	pdus, err := s.bulkWalk(rule.RootOID)
+	if err != nil {
+		return nil, err
+	}
+
+	rows := make(map[string]string, len(pdus))
+	for _, pdu := range pdus {
+		idx := oidIndex(pdu.Name, rule.RootOID)
+		rows[idx] = idx
+	}
+
+	for _, indexOID := range rule.IndexOIDs {
+		pdus, err := s.bulkWalk(indexOID)
+		if err != nil {
+			s.Warningf("walking index OID '%s' for walk '%s': %v", indexOID, rule.Name, err)
+			continue
+		}
+		for _, pdu := range pdus {
+			idx := oidIndex(pdu.Name, indexOID)
+			if _, ok := rows[idx]; ok {
+				rows[idx] = pduValueString(pdu)
+			}
+		}
+	}
+
+	return rows, nil
+}
+
+func (s *SNMP) bulkWalk(rootOID string) ([]gosnmp.SnmpPDU, error) {
+	if s.Version == "1" {
+		return s.snmpClient.WalkAll(rootOID)
+	}
+	return s.snmpClient.BulkWalkAll(rootOID)
+}
+
+// oidIndex returns the trailing index of oid relative to root,
+// e.g. oidIndex(".1.3.6.1.2.1.2.2.1.2.10", ".1.3.6.1.2.1.2.2.1.2") == "10".
+func oidIndex(oid, root string) string {
+	oid = strings.TrimPrefix(oid, ".")
+	root = strings.TrimPrefix(root, ".")
+	return strings.TrimPrefix(strings.TrimPrefix(oid, root), ".")
+}
+
+func pduValueString(pdu gosnmp.SnmpPDU) string {
+	if b, ok := pdu.Value.([]byte); ok {
+		return string(b)
+	}
+	return fmt.Sprint(pdu.Value)
+}
+
+func walkMetricID(ruleName, valueOID, label string) string {
+	name := valueOID
+	if i := strings.LastIndexByte(valueOID, '.'); i != -1 {
+		name = valueOID[i+1:]
+	}
+	return ruleName + "." + name + "." + label
+}
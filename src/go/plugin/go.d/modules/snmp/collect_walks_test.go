@@ -0,0 +1,119 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package snmp
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gosnmp/gosnmp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_oidIndex(t *testing.T) {
+	tests := map[string]struct {
+		oid, root, want string
+	}{
+		"leading dot on both":    {oid: ".1.3.6.1.2.1.2.2.1.2.10", root: ".1.3.6.1.2.1.2.2.1.2", want: "10"},
+		"no leading dot on root": {oid: ".1.3.6.1.2.1.2.2.1.2.10", root: "1.3.6.1.2.1.2.2.1.2", want: "10"},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, test.want, oidIndex(test.oid, test.root))
+		})
+	}
+}
+
+func Test_walkMetricID(t *testing.T) {
+	got := walkMetricID("if", ".1.3.6.1.2.1.31.1.1.1.6", "eth0")
+	assert.Equal(t, "if.6.eth0", got)
+}
+
+const (
+	ifRootOID  = ".1.3.6.1.2.1.2.2.1.1"
+	ifDescrOID = ".1.3.6.1.2.1.2.2.1.2"
+	ifOctetOID = ".1.3.6.1.2.1.31.1.1.1.6"
+)
+
+func TestSNMP_collectWalk_discoversAndCachesRows(t *testing.T) {
+	s := New()
+	rule := WalkRule{
+		Name:      "if",
+		RootOID:   ifRootOID,
+		IndexOIDs: []string{ifDescrOID},
+		ValueOIDs: []string{ifOctetOID},
+	}
+	s.Walks = []WalkRule{rule}
+	s.initWalks()
+
+	mock := &mockSNMPHandler{
+		values: map[string]int64{
+			ifOctetOID + ".1": 100,
+			ifOctetOID + ".2": 200,
+		},
+		walks: map[string][]gosnmp.SnmpPDU{
+			ifRootOID:  {{Name: ifRootOID + ".1"}, {Name: ifRootOID + ".2"}},
+			ifDescrOID: {{Name: ifDescrOID + ".1", Value: []byte("eth0")}, {Name: ifDescrOID + ".2", Value: []byte("eth1")}},
+		},
+	}
+	s.snmpClient = mock
+
+	mx := make(map[string]int64)
+	require.NoError(t, s.collectWalk(mx, s.walks[0]))
+
+	assert.Equal(t, int64(100), mx["if.6.eth0"])
+	assert.Equal(t, int64(200), mx["if.6.eth1"])
+	require.NotNil(t, s.charts.Get(walkChartID("if", "", "eth0")))
+	require.NotNil(t, s.charts.Get(walkChartID("if", "", "eth1")))
+
+	// a second collect within the discovery interval must reuse the cached
+	// rows rather than walking RootOID/IndexOIDs again.
+	mock.walks[ifRootOID] = nil
+	mx = make(map[string]int64)
+	require.NoError(t, s.collectWalk(mx, s.walks[0]))
+	assert.Equal(t, int64(100), mx["if.6.eth0"])
+	assert.Equal(t, int64(200), mx["if.6.eth1"])
+}
+
+func TestSNMP_collectWalk_removesChartsForGoneRows(t *testing.T) {
+	s := New()
+	rule := WalkRule{
+		Name:      "if",
+		RootOID:   ifRootOID,
+		IndexOIDs: []string{ifDescrOID},
+		ValueOIDs: []string{ifOctetOID},
+	}
+	s.Walks = []WalkRule{rule}
+	s.initWalks()
+
+	mock := &mockSNMPHandler{
+		values: map[string]int64{ifOctetOID + ".1": 100, ifOctetOID + ".2": 200},
+		walks: map[string][]gosnmp.SnmpPDU{
+			ifRootOID:  {{Name: ifRootOID + ".1"}, {Name: ifRootOID + ".2"}},
+			ifDescrOID: {{Name: ifDescrOID + ".1", Value: []byte("eth0")}, {Name: ifDescrOID + ".2", Value: []byte("eth1")}},
+		},
+	}
+	s.snmpClient = mock
+
+	require.NoError(t, s.collectWalk(make(map[string]int64), s.walks[0]))
+	require.NotNil(t, s.charts.Get(walkChartID("if", "", "eth1")))
+
+	// force re-discovery and drop the second row ("eth1" / index "2").
+	s.walks[0].lastDiscovery = time.Time{}
+	mock.walks[ifRootOID] = []gosnmp.SnmpPDU{{Name: ifRootOID + ".1"}}
+	mock.walks[ifDescrOID] = []gosnmp.SnmpPDU{{Name: ifDescrOID + ".1", Value: []byte("eth0")}}
+
+	mx := make(map[string]int64)
+	require.NoError(t, s.collectWalk(mx, s.walks[0]))
+
+	assert.Equal(t, int64(100), mx["if.6.eth0"])
+	_, stillCollected := mx["if.6.eth1"]
+	assert.False(t, stillCollected)
+	assert.NotContains(t, s.walks[0].rows, "2")
+
+	eth1Chart := s.charts.Get(walkChartID("if", "", "eth1"))
+	require.NotNil(t, eth1Chart)
+	assert.True(t, eth1Chart.Remove)
+}
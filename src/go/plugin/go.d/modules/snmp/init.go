@@ -0,0 +1,39 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package snmp
+
+import (
+	"errors"
+	"time"
+
+	"github.com/gosnmp/gosnmp"
+)
+
+var (
+	errHostnameRequired = errors.New("'hostname' can not be empty")
+	errEmptyResponse    = errors.New("no metrics collected")
+)
+
+func (s *SNMP) initSNMPClient() (*gosnmp.GoSNMP, error) {
+	client := &gosnmp.GoSNMP{
+		Target:    s.Hostname,
+		Port:      uint16(s.Options.Port),
+		Community: s.Community,
+		Timeout:   time.Duration(s.Options.Timeout) * time.Second,
+		Retries:   s.Options.Retries,
+	}
+
+	switch s.Version {
+	case "1":
+		client.Version = gosnmp.Version1
+	case "3":
+		client.Version = gosnmp.Version3
+		if err := s.setupV3(client); err != nil {
+			return nil, err
+		}
+	default:
+		client.Version = gosnmp.Version2c
+	}
+
+	return client, nil
+}
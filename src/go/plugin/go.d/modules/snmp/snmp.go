@@ -0,0 +1,157 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package snmp
+
+import (
+	"time"
+
+	"github.com/gosnmp/gosnmp"
+
+	"github.com/netdata/netdata/go/plugins/plugin/go.d/agent/module"
+)
+
+func init() {
+	module.Register("snmp", module.Creator{
+		Create: func() module.Module { return New() },
+	})
+}
+
+func New() *SNMP {
+	return &SNMP{
+		Config: Config{
+			Community: "public",
+			Version:   "2c",
+			Options: Options{
+				Port:                  161,
+				Timeout:               5,
+				Retries:               1,
+				MaxOIDs:               60,
+				WalkDiscoveryInterval: 300,
+			},
+		},
+		charts: &module.Charts{},
+	}
+}
+
+// Options holds the knobs that control how the collector talks to the
+// SNMP agent, as opposed to what it asks the agent for.
+type Options struct {
+	Port                  int `yaml:"port,omitempty" json:"port"`
+	Timeout               int `yaml:"timeout,omitempty" json:"timeout"`
+	Retries               int `yaml:"retries,omitempty" json:"retries"`
+	MaxOIDs               int `yaml:"max_request_size,omitempty" json:"max_request_size"`
+	WalkDiscoveryInterval int `yaml:"walk_discovery_interval,omitempty" json:"walk_discovery_interval"`
+}
+
+// Config is the SNMP collector configuration.
+type Config struct {
+	UpdateEvery int    `yaml:"update_every,omitempty" json:"update_every"`
+	Hostname    string `yaml:"hostname" json:"hostname"`
+	Community   string `yaml:"community,omitempty" json:"community"`
+	Version     string `yaml:"version,omitempty" json:"version"`
+
+	Options Options `yaml:"options,omitempty" json:"options"`
+
+	// V3 holds the SNMPv3 USM security parameters, used when Version is "3".
+	V3 V3Config `yaml:"v3,omitempty" json:"v3"`
+
+	// OIDs are collected with plain Get calls, chunked by Options.MaxOIDs.
+	OIDs []string `yaml:"oids,omitempty" json:"oids"`
+
+	// Walks are collected with Get-bulk walks, expanding into per-index metrics.
+	Walks []WalkRule `yaml:"walks,omitempty" json:"walks"`
+}
+
+type SNMP struct {
+	module.Base
+	Config `yaml:",inline" json:""`
+
+	charts *module.Charts
+
+	snmpClient snmpHandler
+
+	customOids []string
+	walks      []*walkState
+}
+
+// snmpHandler is the subset of gosnmp.Handler this collector relies on. It
+// exists so tests can substitute a mock SNMP agent without depending on the
+// full (and larger) gosnmp.Handler interface.
+type snmpHandler interface {
+	Connect() error
+	Close() error
+	Get(oids []string) (*gosnmp.SnmpPacket, error)
+	Walk(rootOid string, walkFn gosnmp.WalkFunc) error
+	BulkWalk(rootOid string, walkFn gosnmp.WalkFunc) error
+	WalkAll(rootOid string) ([]gosnmp.SnmpPDU, error)
+	BulkWalkAll(rootOid string) ([]gosnmp.SnmpPDU, error)
+}
+
+func (s *SNMP) Configuration() any {
+	return s.Config
+}
+
+func (s *SNMP) Init() error {
+	if s.Hostname == "" {
+		return errHostnameRequired
+	}
+
+	client, err := s.initSNMPClient()
+	if err != nil {
+		s.Errorf("creating SNMP client: %v", err)
+		return err
+	}
+	s.snmpClient = client
+
+	// For SNMPv3, Connect also performs the USM engine-ID discovery round
+	// trip required before any authenticated/encrypted request can be sent.
+	if err := s.snmpClient.Connect(); err != nil {
+		s.Errorf("connecting to '%s': %v", s.Hostname, err)
+		return err
+	}
+
+	s.customOids = append([]string(nil), s.OIDs...)
+	s.initWalks()
+
+	return nil
+}
+
+func (s *SNMP) Check() error {
+	mx, err := s.collect()
+	if err != nil {
+		s.Error(err)
+		return err
+	}
+	if len(mx) == 0 {
+		return errEmptyResponse
+	}
+	return nil
+}
+
+func (s *SNMP) Charts() *module.Charts {
+	return s.charts
+}
+
+func (s *SNMP) Collect() map[string]int64 {
+	mx, err := s.collect()
+	if err != nil {
+		s.Error(err)
+	}
+	if len(mx) == 0 {
+		return nil
+	}
+	return mx
+}
+
+func (s *SNMP) Cleanup() {
+	if s.snmpClient != nil {
+		_ = s.snmpClient.Close()
+	}
+}
+
+func (s *SNMP) walkDiscoveryInterval() time.Duration {
+	if s.Options.WalkDiscoveryInterval <= 0 {
+		return 5 * time.Minute
+	}
+	return time.Duration(s.Options.WalkDiscoveryInterval) * time.Second
+}
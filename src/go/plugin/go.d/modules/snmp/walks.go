@@ -0,0 +1,34 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package snmp
+
+// WalkChart is a chart template instantiated once per discovered row.
+type WalkChart struct {
+	IDSuffix string `yaml:"id_suffix" json:"id_suffix"`
+	Title    string `yaml:"title" json:"title"`
+	Units    string `yaml:"units" json:"units"`
+	Type     string `yaml:"type,omitempty" json:"type"`
+
+	// ValueOIDs selects the subset of the rule's ValueOIDs this chart
+	// dimensions on, e.g. splitting one rule's traffic and error counters
+	// across two charts. Empty means all of the rule's ValueOIDs, which is
+	// also what a rule with no Charts at all gets.
+	ValueOIDs []string `yaml:"value_oids,omitempty" json:"value_oids"`
+}
+
+// WalkRule describes a table to discover and collect with Get-bulk walks.
+//
+// RootOID is walked (once per discovery interval) to enumerate the table's
+// row indexes. IndexOIDs, if set, are walked to resolve a human-readable
+// label for each index (e.g. ifDescr for an interface index); when empty the
+// raw numeric index is used. ValueOIDs are fetched on every collection cycle
+// with Get calls for "<valueOID>.<index>" of each discovered row (chunked by
+// Options.MaxOIDs), producing one metric per discovered row, keyed
+// "<Name>.<valueOID name>.<label>".
+type WalkRule struct {
+	Name      string      `yaml:"name" json:"name"`
+	RootOID   string      `yaml:"root_oid" json:"root_oid"`
+	IndexOIDs []string    `yaml:"index_oids,omitempty" json:"index_oids"`
+	ValueOIDs []string    `yaml:"value_oids" json:"value_oids"`
+	Charts    []WalkChart `yaml:"charts,omitempty" json:"charts"`
+}
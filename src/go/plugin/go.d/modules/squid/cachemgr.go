@@ -0,0 +1,161 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package squid
+
+import (
+	"bufio"
+	"context"
+	"encoding/base64"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/netdata/netdata/go/plugins/plugin/go.d/pkg/web"
+)
+
+// cacheMgrClient talks to Squid's native cache manager protocol
+// (cache_object:// / squidclient mgr:) over a plain TCP connection, optionally
+// tunnelled through req.ProxyURL the same way the HTTP transport is.
+//
+// It is the same protocol squidclient uses: a single HTTP/1.0-shaped request
+// line ("GET cache_object://<host>/<menu> HTTP/1.0") is written to the
+// management port, followed by an HTTP-ish response that is read until the
+// connection is closed by Squid.
+type cacheMgrClient struct {
+	addr     string
+	username string
+	password string
+	timeout  time.Duration
+	dial     web.Dialer
+}
+
+// cachemgr menus collected in addition to the default "counters" one.
+var cacheMgrMenus = []string{"mem", "storedir", "ipcache", "fqdncache", "filedescriptors", "5min", "60min"}
+
+// cacheMgrSchemes are the address prefixes accepted for the cachemgr
+// transport's URL, stripped before what's left is treated as a plain
+// "host:port" - cache_object:// is not HTTP and must not be round-tripped
+// through net/url (its scheme isn't even a legal one per RFC 3986).
+var cacheMgrSchemes = []string{"cache_object://", "mgr://"}
+
+func newCacheMgrClient(req web.Request, cfg web.ClientConfig) (*cacheMgrClient, error) {
+	addr := req.URL
+	for _, scheme := range cacheMgrSchemes {
+		addr = strings.TrimPrefix(addr, scheme)
+	}
+	if addr == "" {
+		return nil, fmt.Errorf("invalid cachemgr address in url '%s'", req.URL)
+	}
+	if _, _, err := net.SplitHostPort(addr); err != nil {
+		addr = net.JoinHostPort(addr, "3128")
+	}
+
+	timeout := cfg.Timeout.Duration()
+	if timeout <= 0 {
+		timeout = time.Second * 2
+	}
+
+	dial, err := web.NewDialer(req)
+	if err != nil {
+		return nil, err
+	}
+
+	return &cacheMgrClient{
+		addr:     addr,
+		username: req.Username,
+		password: req.Password,
+		timeout:  timeout,
+		dial:     dial,
+	}, nil
+}
+
+func (c *cacheMgrClient) close() {}
+
+// menu fetches a single cache manager menu (e.g. "counters", "mem", "5min")
+// and returns its body with the leading HTTP-ish response header stripped.
+func (c *cacheMgrClient) menu(name string) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
+	defer cancel()
+
+	conn, err := c.dial(ctx, "tcp", c.addr)
+	if err != nil {
+		return nil, fmt.Errorf("connecting to '%s': %v", c.addr, err)
+	}
+	defer func() { _ = conn.Close() }()
+
+	_ = conn.SetDeadline(time.Now().Add(c.timeout))
+
+	host, _, _ := net.SplitHostPort(c.addr)
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("GET cache_object://%s/%s HTTP/1.0\r\n", host, name))
+	sb.WriteString(fmt.Sprintf("Host: %s\r\n", host))
+	if c.username != "" || c.password != "" {
+		token := base64.StdEncoding.EncodeToString([]byte(c.username + ":" + c.password))
+		sb.WriteString(fmt.Sprintf("Authorization: Basic %s\r\n", token))
+	}
+	sb.WriteString("Accept: */*\r\n\r\n")
+
+	if _, err := conn.Write([]byte(sb.String())); err != nil {
+		return nil, fmt.Errorf("writing request: %v", err)
+	}
+
+	r := bufio.NewReader(conn)
+
+	// skip the HTTP-ish response header block, up to the blank line.
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return nil, fmt.Errorf("reading response header: %v", err)
+		}
+		if strings.TrimSpace(line) == "" {
+			break
+		}
+	}
+
+	var body []byte
+	buf := make([]byte, 4096)
+	for {
+		n, err := r.Read(buf)
+		if n > 0 {
+			body = append(body, buf[:n]...)
+		}
+		if err != nil {
+			break
+		}
+	}
+
+	return body, nil
+}
+
+func (s *Squid) collectCacheMgr() (map[string]int64, error) {
+	mx := make(map[string]int64)
+
+	body, err := s.cacheMgr.menu("counters")
+	if err != nil {
+		return nil, err
+	}
+	if err := parseCounters(strings.NewReader(string(body)), mx); err != nil {
+		return nil, err
+	}
+
+	for _, name := range cacheMgrMenus {
+		body, err := s.cacheMgr.menu(name)
+		if err != nil {
+			s.Warningf("fetching cachemgr menu '%s': %v", name, err)
+			continue
+		}
+		if err := parsePrefixedKeyValues(name, strings.NewReader(string(body)), mx); err != nil {
+			s.Warningf("parsing cachemgr menu '%s': %v", name, err)
+		}
+	}
+
+	if len(mx) == 0 {
+		return nil, errEmptyResponse
+	}
+
+	s.addCacheMgrChartsOnce()
+
+	return mx, nil
+}
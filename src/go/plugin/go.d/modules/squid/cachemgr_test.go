@@ -0,0 +1,169 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package squid
+
+import (
+	"bufio"
+	"net"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/netdata/netdata/go/plugins/plugin/go.d/pkg/web"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	dataCacheMgrCounters, _        = os.ReadFile("testdata/cachemgr_counters.txt")
+	dataCacheMgrMem, _             = os.ReadFile("testdata/cachemgr_mem.txt")
+	dataCacheMgrStoreDir, _        = os.ReadFile("testdata/cachemgr_storedir.txt")
+	dataCacheMgrIPCache, _         = os.ReadFile("testdata/cachemgr_ipcache.txt")
+	dataCacheMgrFQDNCache, _       = os.ReadFile("testdata/cachemgr_fqdncache.txt")
+	dataCacheMgrFileDescriptors, _ = os.ReadFile("testdata/cachemgr_filedescriptors.txt")
+	dataCacheMgr5Min, _            = os.ReadFile("testdata/cachemgr_5min.txt")
+)
+
+func Test_newCacheMgrClient_addr(t *testing.T) {
+	tests := map[string]struct {
+		url      string
+		wantAddr string
+		wantErr  bool
+	}{
+		"cache_object scheme with port":    {url: "cache_object://127.0.0.1:3128", wantAddr: "127.0.0.1:3128"},
+		"cache_object scheme without port": {url: "cache_object://squid.local", wantAddr: "squid.local:3128"},
+		"bare host:port, no scheme":        {url: "127.0.0.1:3128", wantAddr: "127.0.0.1:3128"},
+		"bare host, no scheme or port":     {url: "squid.local", wantAddr: "squid.local:3128"},
+		"empty":                            {url: "", wantErr: true},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			req := web.Request{URL: test.url}
+
+			cl, err := newCacheMgrClient(req, web.ClientConfig{})
+
+			if test.wantErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, test.wantAddr, cl.addr)
+		})
+	}
+}
+
+func TestSquid_Collect_CacheMgr(t *testing.T) {
+	require.NotNil(t, dataCacheMgrCounters)
+	require.NotNil(t, dataCacheMgrMem)
+	require.NotNil(t, dataCacheMgrStoreDir)
+	require.NotNil(t, dataCacheMgrIPCache)
+	require.NotNil(t, dataCacheMgrFQDNCache)
+	require.NotNil(t, dataCacheMgrFileDescriptors)
+	require.NotNil(t, dataCacheMgr5Min)
+
+	addr, cleanup := prepareCacheMgrServer(t)
+	defer cleanup()
+
+	squid := New()
+	squid.Transport = transportCacheMgr
+	squid.URL = "cache_object://" + addr
+	require.NoError(t, squid.Init())
+
+	mx := squid.Collect()
+
+	require.NotNil(t, mx)
+	assert.Equal(t, int64(9019), mx["client_http.requests"])
+	assert.Equal(t, int64(12345), mx["mem.total_accounted"])
+	assert.Equal(t, int64(678), mx["mem.total_unaccounted"])
+	assert.Equal(t, int64(1000000), mx["storedir.capacity"])
+	assert.Equal(t, int64(500000), mx["storedir.used"])
+	assert.Equal(t, int64(87), mx["ipcache.hit_ratio"])
+	assert.Equal(t, int64(91), mx["fqdncache.hit_ratio"])
+	assert.Equal(t, int64(120), mx["filedescriptors.used"])
+	assert.Equal(t, int64(1024), mx["filedescriptors.max"])
+	// "5min"/"60min" rate values carry a "/sec" suffix - confirm it's
+	// stripped rather than silently dropping the whole counter.
+	assert.Equal(t, int64(12), mx["5min.client_http.requests"])
+
+	for _, id := range []string{"cachemgr_mem", "cachemgr_storedir", "cachemgr_ipcache", "cachemgr_fqdncache", "cachemgr_filedescriptors"} {
+		chart := squid.Charts().Get(id)
+		require.NotNilf(t, chart, "chart '%s' not found", id)
+		for _, dim := range chart.Dims {
+			_, ok := mx[dim.ID]
+			assert.Truef(t, ok, "chart '%s' dim '%s' not found in collected metrics", id, dim.ID)
+		}
+	}
+}
+
+func TestSquid_Collect_CacheMgr_viaHTTPProxy(t *testing.T) {
+	addr, cleanup := prepareCacheMgrServer(t)
+	defer cleanup()
+
+	proxyAddr, cleanupProxy := prepareConnectProxy(t)
+	defer cleanupProxy()
+
+	squid := New()
+	squid.Transport = transportCacheMgr
+	squid.URL = "cache_object://" + addr
+	squid.ProxyURL = "http://" + proxyAddr
+	require.NoError(t, squid.Init())
+
+	mx := squid.Collect()
+
+	require.NotNil(t, mx)
+	assert.Equal(t, int64(9019), mx["client_http.requests"])
+}
+
+// prepareCacheMgrServer starts a bare TCP listener that speaks just enough of
+// the cache_object:// protocol to serve the "counters", "mem", "storedir",
+// "ipcache", "fqdncache", "filedescriptors" and "5min" menus used in tests;
+// any other menu is answered with an empty body.
+func prepareCacheMgrServer(t *testing.T) (string, func()) {
+	t.Helper()
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go handleCacheMgrConn(conn)
+		}
+	}()
+
+	return ln.Addr().String(), func() { _ = ln.Close() }
+}
+
+func handleCacheMgrConn(conn net.Conn) {
+	defer func() { _ = conn.Close() }()
+
+	line, err := bufio.NewReader(conn).ReadString('\n')
+	if err != nil {
+		return
+	}
+
+	switch {
+	case strings.Contains(line, "/counters "):
+		_, _ = conn.Write(dataCacheMgrCounters)
+	case strings.Contains(line, "/mem "):
+		_, _ = conn.Write(dataCacheMgrMem)
+	case strings.Contains(line, "/storedir "):
+		_, _ = conn.Write(dataCacheMgrStoreDir)
+	case strings.Contains(line, "/ipcache "):
+		_, _ = conn.Write(dataCacheMgrIPCache)
+	case strings.Contains(line, "/fqdncache "):
+		_, _ = conn.Write(dataCacheMgrFQDNCache)
+	case strings.Contains(line, "/filedescriptors "):
+		_, _ = conn.Write(dataCacheMgrFileDescriptors)
+	case strings.Contains(line, "/5min "):
+		_, _ = conn.Write(dataCacheMgr5Min)
+	default:
+		_, _ = conn.Write([]byte("HTTP/1.0 200 OK\r\n\r\n"))
+	}
+}
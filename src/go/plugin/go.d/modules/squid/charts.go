@@ -0,0 +1,192 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package squid
+
+import (
+	"github.com/netdata/netdata/go/plugins/plugin/go.d/agent/module"
+)
+
+const (
+	prioClientHTTPRequests = module.Priority + iota
+	prioClientHTTPTraffic
+	prioClientHTTPHits
+	prioClientHTTPErrors
+	prioServerAllRequests
+	prioServerAllTraffic
+	prioServerAllErrors
+
+	prioCacheMgrMemory
+	prioCacheMgrStoreDir
+	prioCacheMgrIPCacheHitRatio
+	prioCacheMgrFQDNCacheHitRatio
+	prioCacheMgrFileDescriptors
+)
+
+var charts = module.Charts{
+	{
+		ID:       "client_http_requests",
+		Title:    "Client HTTP Requests",
+		Units:    "requests/s",
+		Fam:      "client http",
+		Ctx:      "squid.client_http_requests",
+		Priority: prioClientHTTPRequests,
+		Dims: module.Dims{
+			{ID: "client_http.requests", Name: "requests", Algo: module.Incremental},
+		},
+	},
+	{
+		ID:       "client_http_traffic",
+		Title:    "Client HTTP Traffic",
+		Units:    "KiB/s",
+		Fam:      "client http",
+		Ctx:      "squid.client_http_traffic",
+		Priority: prioClientHTTPTraffic,
+		Dims: module.Dims{
+			{ID: "client_http.kbytes_in", Name: "in", Algo: module.Incremental},
+			{ID: "client_http.kbytes_out", Name: "out", Algo: module.Incremental, Mul: -1},
+			{ID: "client_http.hit_kbytes_out", Name: "hits", Algo: module.Incremental, Mul: -1},
+		},
+	},
+	{
+		ID:       "client_http_hits",
+		Title:    "Client HTTP Hits",
+		Units:    "hits/s",
+		Fam:      "client http",
+		Ctx:      "squid.client_http_hits",
+		Priority: prioClientHTTPHits,
+		Dims: module.Dims{
+			{ID: "client_http.hits", Name: "hits", Algo: module.Incremental},
+		},
+	},
+	{
+		ID:       "client_http_errors",
+		Title:    "Client HTTP Errors",
+		Units:    "errors/s",
+		Fam:      "client http",
+		Ctx:      "squid.client_http_errors",
+		Priority: prioClientHTTPErrors,
+		Dims: module.Dims{
+			{ID: "client_http.errors", Name: "errors", Algo: module.Incremental},
+		},
+	},
+	{
+		ID:       "server_all_requests",
+		Title:    "Server All Requests",
+		Units:    "requests/s",
+		Fam:      "server all",
+		Ctx:      "squid.server_all_requests",
+		Priority: prioServerAllRequests,
+		Dims: module.Dims{
+			{ID: "server.all.requests", Name: "requests", Algo: module.Incremental},
+		},
+	},
+	{
+		ID:       "server_all_traffic",
+		Title:    "Server All Traffic",
+		Units:    "KiB/s",
+		Fam:      "server all",
+		Ctx:      "squid.server_all_traffic",
+		Priority: prioServerAllTraffic,
+		Dims: module.Dims{
+			{ID: "server.all.kbytes_in", Name: "in", Algo: module.Incremental},
+			{ID: "server.all.kbytes_out", Name: "out", Algo: module.Incremental, Mul: -1},
+		},
+	},
+	{
+		ID:       "server_all_errors",
+		Title:    "Server All Errors",
+		Units:    "errors/s",
+		Fam:      "server all",
+		Ctx:      "squid.server_all_errors",
+		Priority: prioServerAllErrors,
+		Dims: module.Dims{
+			{ID: "server.all.errors", Name: "errors", Algo: module.Incremental},
+		},
+	},
+}
+
+// cachemgr-only charts, all added together on the first successful cachemgr
+// collection, whether or not each individual menu returned data.
+
+var cacheMgrMemoryChart = module.Chart{
+	ID:       "cachemgr_mem",
+	Title:    "Memory Pools Usage",
+	Units:    "KiB",
+	Fam:      "cache manager",
+	Ctx:      "squid.cachemgr_memory",
+	Priority: prioCacheMgrMemory,
+	Dims: module.Dims{
+		{ID: "mem.total_accounted", Name: "accounted"},
+		{ID: "mem.total_unaccounted", Name: "unaccounted"},
+	},
+}
+
+var cacheMgrStoreDirChart = module.Chart{
+	ID:       "cachemgr_storedir",
+	Title:    "Store Directory Usage",
+	Units:    "KiB",
+	Fam:      "cache manager",
+	Ctx:      "squid.cachemgr_storedir",
+	Priority: prioCacheMgrStoreDir,
+	Dims: module.Dims{
+		{ID: "storedir.capacity", Name: "capacity"},
+		{ID: "storedir.used", Name: "used"},
+	},
+}
+
+var cacheMgrIPCacheChart = module.Chart{
+	ID:       "cachemgr_ipcache",
+	Title:    "IP Cache Hit Ratio",
+	Units:    "percentage",
+	Fam:      "cache manager",
+	Ctx:      "squid.cachemgr_ipcache_hit_ratio",
+	Priority: prioCacheMgrIPCacheHitRatio,
+	Dims: module.Dims{
+		{ID: "ipcache.hit_ratio", Name: "hit_ratio"},
+	},
+}
+
+var cacheMgrFQDNCacheChart = module.Chart{
+	ID:       "cachemgr_fqdncache",
+	Title:    "FQDN Cache Hit Ratio",
+	Units:    "percentage",
+	Fam:      "cache manager",
+	Ctx:      "squid.cachemgr_fqdncache_hit_ratio",
+	Priority: prioCacheMgrFQDNCacheHitRatio,
+	Dims: module.Dims{
+		{ID: "fqdncache.hit_ratio", Name: "hit_ratio"},
+	},
+}
+
+var cacheMgrFileDescriptorsChart = module.Chart{
+	ID:       "cachemgr_filedescriptors",
+	Title:    "File Descriptors Usage",
+	Units:    "descriptors",
+	Fam:      "cache manager",
+	Ctx:      "squid.cachemgr_filedescriptors",
+	Priority: prioCacheMgrFileDescriptors,
+	Dims: module.Dims{
+		{ID: "filedescriptors.used", Name: "used"},
+		{ID: "filedescriptors.max", Name: "max"},
+	},
+}
+
+func (s *Squid) addCacheMgrChartsOnce() {
+	if s.cacheMgrChartsAdded {
+		return
+	}
+	s.cacheMgrChartsAdded = true
+
+	charts := []*module.Chart{
+		cacheMgrMemoryChart.Copy(),
+		cacheMgrStoreDirChart.Copy(),
+		cacheMgrIPCacheChart.Copy(),
+		cacheMgrFQDNCacheChart.Copy(),
+		cacheMgrFileDescriptorsChart.Copy(),
+	}
+	for _, chart := range charts {
+		if err := s.charts.Add(chart); err != nil {
+			s.Warning(err)
+		}
+	}
+}
@@ -0,0 +1,101 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package squid
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+const urlPathServerStats = "/squid-internal-mgr/counters"
+
+var errEmptyResponse = errors.New("empty response")
+
+func (s *Squid) collect() (map[string]int64, error) {
+	if s.cacheMgr != nil {
+		return s.collectCacheMgr()
+	}
+	return s.collectHTTP()
+}
+
+func (s *Squid) collectHTTP() (map[string]int64, error) {
+	req, err := http.NewRequest(http.MethodGet, s.URL+urlPathServerStats, nil)
+	if err != nil {
+		return nil, fmt.Errorf("creating request: %v", err)
+	}
+	if s.Username != "" || s.Password != "" {
+		req.SetBasicAuth(s.Username, s.Password)
+	}
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("executing request: %v", err)
+	}
+	defer closeBody(resp)
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected HTTP status code: %d", resp.StatusCode)
+	}
+
+	mx := make(map[string]int64)
+	if err := parseCounters(resp.Body, mx); err != nil {
+		return nil, err
+	}
+	if len(mx) == 0 {
+		return nil, errEmptyResponse
+	}
+
+	return mx, nil
+}
+
+// parseCounters parses the "counters" cache manager menu (key = value per line,
+// as returned by both the HTTP urlPathServerStats endpoint and the cachemgr
+// "counters" menu over cache_object://).
+func parseCounters(r io.Reader, mx map[string]int64) error {
+	sc := bufio.NewScanner(r)
+	for sc.Scan() {
+		line := strings.TrimSpace(sc.Text())
+		if line == "" || !strings.Contains(line, "=") {
+			continue
+		}
+
+		parts := strings.SplitN(line, "=", 2)
+		key := strings.TrimSpace(parts[0])
+		value := strings.TrimSpace(parts[1])
+		// the "5min"/"60min" menus report rate counters as e.g. "1.23/sec" -
+		// strip the suffix so those aren't silently dropped by ParseFloat.
+		value = strings.TrimSuffix(value, "/sec")
+
+		v, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			continue
+		}
+
+		mx[key] = int64(v)
+	}
+	return sc.Err()
+}
+
+// parsePrefixedKeyValues parses "key = value" lines from a cachemgr menu and
+// stores them in mx as "<prefix>.<key>", e.g. menu "mem" key "total_accounted"
+// becomes "mem.total_accounted".
+func parsePrefixedKeyValues(prefix string, r io.Reader, mx map[string]int64) error {
+	tmp := make(map[string]int64)
+	if err := parseCounters(r, tmp); err != nil {
+		return err
+	}
+	for k, v := range tmp {
+		mx[prefix+"."+k] = v
+	}
+	return nil
+}
+
+func closeBody(resp *http.Response) {
+	_, _ = io.Copy(io.Discard, resp.Body)
+	_ = resp.Body.Close()
+}
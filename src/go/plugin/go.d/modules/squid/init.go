@@ -0,0 +1,23 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package squid
+
+import "errors"
+
+func (s *Squid) validateConfig() error {
+	if s.URL == "" {
+		return errors.New("'url' can not be empty")
+	}
+
+	switch s.Transport {
+	case "", transportHTTP, transportCacheMgr:
+	default:
+		return errors.New("'transport' must be either 'http' or 'cachemgr'")
+	}
+
+	return nil
+}
+
+func (s *Squid) initCacheMgrClient() (*cacheMgrClient, error) {
+	return newCacheMgrClient(s.Request, s.Client)
+}
@@ -0,0 +1,128 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package squid
+
+import (
+	"bufio"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSquid_Check_viaHTTPProxy(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			switch r.URL.Path {
+			case urlPathServerStats:
+				_, _ = w.Write(dataCounters)
+			default:
+				w.WriteHeader(http.StatusNotFound)
+			}
+		}))
+	defer srv.Close()
+
+	proxyAddr, cleanup := prepareForwardProxy(t)
+	defer cleanup()
+
+	squid := New()
+	squid.URL = srv.URL
+	squid.ProxyURL = "http://" + proxyAddr
+	require.NoError(t, squid.Init())
+
+	require.NoError(t, squid.Check())
+}
+
+// prepareForwardProxy starts a bare-bones forward HTTP proxy: for a
+// plain-http:// target, net/http.Transport never issues CONNECT - it sends
+// the request with an absolute-URI request line straight to the proxy, which
+// is expected to round-trip it to the real target itself. prepareConnectProxy
+// doesn't handle that path, so squid requests proxied to an http:// (as
+// opposed to https://) target need this instead.
+func prepareForwardProxy(t *testing.T) (string, func()) {
+	t.Helper()
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go handleForward(conn)
+		}
+	}()
+
+	return ln.Addr().String(), func() { _ = ln.Close() }
+}
+
+func handleForward(conn net.Conn) {
+	defer func() { _ = conn.Close() }()
+
+	r := bufio.NewReader(conn)
+	req, err := http.ReadRequest(r)
+	if err != nil {
+		return
+	}
+	req.RequestURI = ""
+
+	resp, err := http.DefaultTransport.RoundTrip(req)
+	if err != nil {
+		_, _ = conn.Write([]byte("HTTP/1.1 502 Bad Gateway\r\n\r\n"))
+		return
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	_ = resp.Write(conn)
+}
+
+// prepareConnectProxy starts a bare-bones HTTP CONNECT tunnel: it accepts a
+// CONNECT request, dials the requested address, answers 200, and then
+// splices the two connections together.
+func prepareConnectProxy(t *testing.T) (string, func()) {
+	t.Helper()
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go handleConnect(conn)
+		}
+	}()
+
+	return ln.Addr().String(), func() { _ = ln.Close() }
+}
+
+func handleConnect(conn net.Conn) {
+	defer func() { _ = conn.Close() }()
+
+	r := bufio.NewReader(conn)
+	req, err := http.ReadRequest(r)
+	if err != nil || req.Method != http.MethodConnect {
+		return
+	}
+
+	target, err := net.Dial("tcp", req.Host)
+	if err != nil {
+		_, _ = conn.Write([]byte("HTTP/1.1 502 Bad Gateway\r\n\r\n"))
+		return
+	}
+	defer func() { _ = target.Close() }()
+
+	_, _ = conn.Write([]byte("HTTP/1.1 200 Connection Established\r\n\r\n"))
+
+	done := make(chan struct{}, 2)
+	go func() { _, _ = io.Copy(target, r); done <- struct{}{} }()
+	go func() { _, _ = io.Copy(conn, target); done <- struct{}{} }()
+	<-done
+}
@@ -0,0 +1,125 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package squid
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/netdata/netdata/go/plugins/plugin/go.d/agent/module"
+	"github.com/netdata/netdata/go/plugins/plugin/go.d/pkg/web"
+)
+
+func init() {
+	module.Register("squid", module.Creator{
+		Create: func() module.Module { return New() },
+	})
+}
+
+const (
+	transportHTTP     = "http"
+	transportCacheMgr = "cachemgr"
+)
+
+func New() *Squid {
+	return &Squid{
+		Config: Config{
+			HTTP: web.HTTP{
+				Request: web.Request{URL: "http://127.0.0.1:3128"},
+				Client:  web.ClientConfig{Timeout: web.Duration(time.Second * 2)},
+			},
+			Transport: transportHTTP,
+		},
+		charts: charts.Copy(),
+	}
+}
+
+type Config struct {
+	UpdateEvery int `yaml:"update_every,omitempty" json:"update_every"`
+	web.HTTP    `yaml:",inline" json:""`
+
+	// Transport selects how metrics are collected: "http" (urlPathServerStats)
+	// or "cachemgr" (cache_object:// / squidclient mgr: protocol). Username
+	// and Password (inherited from web.HTTP.Request) authenticate against the
+	// manager ACL when using the cachemgr transport.
+	Transport string `yaml:"transport,omitempty" json:"transport"`
+}
+
+type Squid struct {
+	module.Base
+	Config `yaml:",inline" json:""`
+
+	charts *module.Charts
+
+	httpClient *http.Client
+	cacheMgr   *cacheMgrClient
+
+	cacheMgrChartsAdded bool
+}
+
+func (s *Squid) Configuration() any {
+	return s.Config
+}
+
+func (s *Squid) Init() error {
+	if err := s.validateConfig(); err != nil {
+		s.Errorf("validating config: %v", err)
+		return err
+	}
+
+	switch s.Transport {
+	case transportCacheMgr:
+		cl, err := s.initCacheMgrClient()
+		if err != nil {
+			s.Errorf("initializing cache manager client: %v", err)
+			return err
+		}
+		s.cacheMgr = cl
+	default:
+		httpClient, err := web.NewHTTPClient(s.Request, s.Client)
+		if err != nil {
+			s.Errorf("initializing HTTP client: %v", err)
+			return err
+		}
+		s.httpClient = httpClient
+	}
+
+	return nil
+}
+
+func (s *Squid) Check() error {
+	mx, err := s.collect()
+	if err != nil {
+		s.Error(err)
+		return err
+	}
+	if len(mx) == 0 {
+		return errEmptyResponse
+	}
+	return nil
+}
+
+func (s *Squid) Charts() *module.Charts {
+	return s.charts
+}
+
+func (s *Squid) Collect() map[string]int64 {
+	mx, err := s.collect()
+	if err != nil {
+		s.Error(err)
+	}
+
+	if len(mx) == 0 {
+		return nil
+	}
+	return mx
+}
+
+func (s *Squid) Cleanup() {
+	if s.httpClient != nil {
+		s.httpClient.CloseIdleConnections()
+	}
+	if s.cacheMgr != nil {
+		s.cacheMgr.close()
+	}
+}
@@ -10,6 +10,7 @@ import (
 
 	"github.com/netdata/netdata/go/plugins/plugin/go.d/agent/module"
 	"github.com/netdata/netdata/go/plugins/plugin/go.d/pkg/web"
+	"github.com/netdata/netdata/go/plugins/plugin/go.d/pkg/webtest"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -159,17 +160,32 @@ func TestSquid_Collect(t *testing.T) {
 	}
 }
 
+// prepareCaseSuccess replays a HAR fixture captured against a real Squid
+// instance. Re-capture it with `go test -record` against a live instance
+// listening on New()'s default URL if the cache manager "counters" response
+// shape ever changes.
 func prepareCaseSuccess(t *testing.T) (*Squid, func()) {
 	t.Helper()
-	srv := httptest.NewServer(http.HandlerFunc(
-		func(w http.ResponseWriter, r *http.Request) {
-			switch r.URL.Path {
-			case urlPathServerStats:
-				_, _ = w.Write(dataCounters)
-			default:
-				w.WriteHeader(http.StatusNotFound)
-			}
-		}))
+
+	if *webtest.Record {
+		recorder, err := webtest.NewRecorder(New().URL)
+		require.NoError(t, err)
+		srv := httptest.NewServer(recorder)
+
+		squid := New()
+		squid.URL = srv.URL
+		require.NoError(t, squid.Init())
+
+		return squid, func() {
+			srv.Close()
+			require.NoError(t, recorder.Save("testdata/success.har.json"))
+		}
+	}
+
+	rec, err := webtest.LoadHAR("testdata/success.har.json")
+	require.NoError(t, err)
+
+	srv := httptest.NewServer(rec.Handler())
 
 	squid := New()
 	squid.URL = srv.URL
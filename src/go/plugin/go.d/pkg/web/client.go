@@ -0,0 +1,40 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package web
+
+import (
+	"net/http"
+	"time"
+)
+
+// Duration is a time.Duration that marshals to/from a plain number of seconds.
+type Duration time.Duration
+
+// Duration returns d as a time.Duration.
+func (d Duration) Duration() time.Duration { return time.Duration(d) }
+
+// ClientConfig is the configuration of an HTTP client.
+type ClientConfig struct {
+	Timeout            Duration `yaml:"timeout,omitempty" json:"timeout"`
+	NotFollowRedirects bool     `yaml:"not_follow_redirects,omitempty" json:"not_follow_redirects"`
+}
+
+// NewHTTPClient creates an *http.Client configured according to req and cfg.
+func NewHTTPClient(req Request, cfg ClientConfig) (*http.Client, error) {
+	transport, err := newTransport(req)
+	if err != nil {
+		return nil, err
+	}
+
+	client := &http.Client{
+		Timeout:   cfg.Timeout.Duration(),
+		Transport: transport,
+	}
+	if cfg.NotFollowRedirects {
+		client.CheckRedirect = func(_ *http.Request, _ []*http.Request) error {
+			return http.ErrUseLastResponse
+		}
+	}
+
+	return client, nil
+}
@@ -0,0 +1,104 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package web
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+
+	"golang.org/x/net/proxy"
+)
+
+// Dialer opens a TCP connection, optionally tunnelled through a proxy.
+type Dialer func(ctx context.Context, network, addr string) (net.Conn, error)
+
+// NewDialer returns a Dialer honoring req.ProxyURL ("http://", "https://" or
+// "socks5://"). It is the raw-TCP counterpart of NewHTTPClient, for
+// protocols - like Squid's cache manager - that need a plain connection
+// through a proxy rather than an *http.Client.
+func NewDialer(req Request) (Dialer, error) {
+	if req.ProxyURL == "" {
+		return (&net.Dialer{}).DialContext, nil
+	}
+
+	u, err := url.Parse(req.ProxyURL)
+	if err != nil {
+		return nil, fmt.Errorf("parsing proxy_url '%s': %v", req.ProxyURL, err)
+	}
+
+	switch u.Scheme {
+	case "http", "https":
+		return httpConnectDialer(u, req), nil
+	case "socks5":
+		return socks5Dialer(u, req)
+	default:
+		return nil, fmt.Errorf("unsupported proxy_url scheme '%s'", u.Scheme)
+	}
+}
+
+func socks5Dialer(proxyURL *url.URL, req Request) (Dialer, error) {
+	var auth *proxy.Auth
+	if req.ProxyUsername != "" || req.ProxyPassword != "" {
+		auth = &proxy.Auth{User: req.ProxyUsername, Password: req.ProxyPassword}
+	}
+
+	dialer, err := proxy.SOCKS5("tcp", proxyURL.Host, auth, proxy.Direct)
+	if err != nil {
+		return nil, fmt.Errorf("creating SOCKS5 dialer for '%s': %v", proxyURL, err)
+	}
+
+	return func(_ context.Context, network, addr string) (net.Conn, error) {
+		return dialer.Dial(network, addr)
+	}, nil
+}
+
+// httpConnectDialer returns a Dialer that establishes a CONNECT tunnel
+// through an http(s):// proxy and hands back the tunnelled connection.
+func httpConnectDialer(proxyURL *url.URL, req Request) Dialer {
+	return func(ctx context.Context, network, addr string) (net.Conn, error) {
+		conn, err := (&net.Dialer{}).DialContext(ctx, network, proxyURL.Host)
+		if err != nil {
+			return nil, fmt.Errorf("dialing proxy '%s': %v", proxyURL.Host, err)
+		}
+		if proxyURL.Scheme == "https" {
+			conn = tls.Client(conn, &tls.Config{ServerName: proxyURL.Hostname()})
+		}
+
+		connectReq := &http.Request{
+			Method: http.MethodConnect,
+			URL:    &url.URL{Opaque: addr},
+			Host:   addr,
+			Header: make(http.Header, len(req.ProxyHeaders)),
+		}
+		for k, v := range req.ProxyHeaders {
+			connectReq.Header.Set(k, v)
+		}
+		if req.ProxyUsername != "" || req.ProxyPassword != "" {
+			connectReq.SetBasicAuth(req.ProxyUsername, req.ProxyPassword)
+		}
+
+		if err := connectReq.Write(conn); err != nil {
+			_ = conn.Close()
+			return nil, fmt.Errorf("writing CONNECT request to '%s': %v", proxyURL.Host, err)
+		}
+
+		resp, err := http.ReadResponse(bufio.NewReader(conn), connectReq)
+		if err != nil {
+			_ = conn.Close()
+			return nil, fmt.Errorf("reading CONNECT response from '%s': %v", proxyURL.Host, err)
+		}
+		_ = resp.Body.Close()
+
+		if resp.StatusCode != http.StatusOK {
+			_ = conn.Close()
+			return nil, fmt.Errorf("proxy '%s' refused CONNECT to '%s': %s", proxyURL.Host, addr, resp.Status)
+		}
+
+		return conn, nil
+	}
+}
@@ -0,0 +1,10 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+// Package web provides shared HTTP request/client configuration for collectors.
+package web
+
+// HTTP is the configuration of an HTTP request and the client used to perform it.
+type HTTP struct {
+	Request `yaml:",inline" json:""`
+	Client  ClientConfig `yaml:",inline" json:""`
+}
@@ -0,0 +1,38 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package web
+
+// Request is the configuration of an HTTP request.
+type Request struct {
+	URL      string `yaml:"url" json:"url"`
+	Username string `yaml:"username,omitempty" json:"username"`
+	Password string `yaml:"password,omitempty" json:"password"`
+
+	// ProxyURL is the address of a proxy to reach URL through. Supported
+	// schemes are "http://", "https://" and "socks5://".
+	ProxyURL      string `yaml:"proxy_url,omitempty" json:"proxy_url"`
+	ProxyUsername string `yaml:"proxy_username,omitempty" json:"proxy_username"`
+	ProxyPassword string `yaml:"proxy_password,omitempty" json:"proxy_password"`
+	// ProxyHeaders are sent on the CONNECT request used to establish an
+	// http(s):// proxy tunnel, e.g. a pre-computed "Proxy-Authorization".
+	ProxyHeaders map[string]string `yaml:"proxy_headers,omitempty" json:"proxy_headers"`
+
+	Headers map[string]string `yaml:"headers,omitempty" json:"headers"`
+}
+
+// Copy returns a deep copy of Request.
+func (r Request) Copy() Request {
+	headers := make(map[string]string, len(r.Headers))
+	for k, v := range r.Headers {
+		headers[k] = v
+	}
+	r.Headers = headers
+
+	proxyHeaders := make(map[string]string, len(r.ProxyHeaders))
+	for k, v := range r.ProxyHeaders {
+		proxyHeaders[k] = v
+	}
+	r.ProxyHeaders = proxyHeaders
+
+	return r
+}
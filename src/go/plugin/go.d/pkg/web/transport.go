@@ -0,0 +1,50 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package web
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// newTransport builds the *http.Transport to use for req, wiring up
+// req.ProxyURL ("http://", "https://" or "socks5://") when set.
+func newTransport(req Request) (*http.Transport, error) {
+	tr := &http.Transport{}
+
+	if req.ProxyURL == "" {
+		return tr, nil
+	}
+
+	u, err := url.Parse(req.ProxyURL)
+	if err != nil {
+		return nil, fmt.Errorf("parsing proxy_url '%s': %v", req.ProxyURL, err)
+	}
+
+	switch u.Scheme {
+	case "http", "https":
+		if req.ProxyUsername != "" || req.ProxyPassword != "" {
+			u.User = url.UserPassword(req.ProxyUsername, req.ProxyPassword)
+		}
+		tr.Proxy = http.ProxyURL(u)
+
+		if len(req.ProxyHeaders) > 0 {
+			headers := make(http.Header, len(req.ProxyHeaders))
+			for k, v := range req.ProxyHeaders {
+				headers.Set(k, v)
+			}
+			tr.ProxyConnectHeader = headers
+		}
+	case "socks5":
+		dial, err := socks5Dialer(u, req)
+		if err != nil {
+			return nil, err
+		}
+		tr.DialContext = dial
+	default:
+		return nil, fmt.Errorf("unsupported proxy_url scheme '%s'", u.Scheme)
+	}
+
+	return tr, nil
+}
@@ -0,0 +1,48 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package web
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewTransport(t *testing.T) {
+	tests := map[string]struct {
+		req     Request
+		wantErr bool
+	}{
+		"no proxy":       {req: Request{}},
+		"http proxy":     {req: Request{ProxyURL: "http://proxy.example.com:3128"}},
+		"https proxy":    {req: Request{ProxyURL: "https://proxy.example.com:3128"}},
+		"socks5 proxy":   {req: Request{ProxyURL: "socks5://proxy.example.com:1080"}},
+		"unknown scheme": {req: Request{ProxyURL: "ftp://proxy.example.com"}, wantErr: true},
+		"invalid url":    {req: Request{ProxyURL: "://bad"}, wantErr: true},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			tr, err := newTransport(test.req)
+
+			if test.wantErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.NotNil(t, tr)
+		})
+	}
+}
+
+func TestNewTransport_httpProxyConnectHeaders(t *testing.T) {
+	tr, err := newTransport(Request{
+		ProxyURL:     "http://proxy.example.com:3128",
+		ProxyHeaders: map[string]string{"Proxy-Authorization": "Basic dXNlcjpwYXNz"},
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, "Basic dXNlcjpwYXNz", tr.ProxyConnectHeader.Get("Proxy-Authorization"))
+}
@@ -0,0 +1,65 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+// Package webtest records real HTTP backend interactions into small HAR-like
+// JSON fixtures and replays them as an http.Handler, so collector tests don't
+// have to hand-roll httptest.Server handlers for every response shape.
+package webtest
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+)
+
+// Request is the recorded half of an HTTP request.
+type Request struct {
+	Method  string            `json:"method"`
+	URL     string            `json:"url"`
+	Headers map[string]string `json:"headers,omitempty"`
+}
+
+// Response is the recorded half of an HTTP response.
+type Response struct {
+	StatusCode int               `json:"status_code"`
+	Headers    map[string]string `json:"headers,omitempty"`
+	Body       string            `json:"body"`
+}
+
+// Interaction is a single recorded request/response pair.
+type Interaction struct {
+	Request  Request  `json:"request"`
+	Response Response `json:"response"`
+}
+
+// Recording is an ordered list of interactions captured against a real
+// backend, e.g. with Recorder.
+type Recording struct {
+	Interactions []Interaction `json:"interactions"`
+}
+
+// LoadHAR reads a Recording from a HAR-like JSON fixture file.
+func LoadHAR(path string) (*Recording, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading HAR fixture '%s': %v", path, err)
+	}
+
+	var rec Recording
+	if err := json.Unmarshal(b, &rec); err != nil {
+		return nil, fmt.Errorf("parsing HAR fixture '%s': %v", path, err)
+	}
+
+	return &rec, nil
+}
+
+// SaveHAR writes rec to path as indented JSON.
+func SaveHAR(path string, rec *Recording) error {
+	b, err := json.MarshalIndent(rec, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encoding HAR fixture: %v", err)
+	}
+	if err := os.WriteFile(path, b, 0644); err != nil {
+		return fmt.Errorf("writing HAR fixture '%s': %v", path, err)
+	}
+	return nil
+}
@@ -0,0 +1,75 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package webtest
+
+import (
+	"bytes"
+	"flag"
+	"io"
+	"net/http"
+	"net/http/httputil"
+	"net/url"
+)
+
+// Record, when passed as `go test -record`, tells tests built on this
+// package to capture fresh HAR fixtures against a live backend instead of
+// replaying the checked-in ones.
+var Record = flag.Bool("record", false, "record HAR fixtures against a live backend instead of replaying them")
+
+// Recorder is an http.Handler that proxies every request to target and
+// records the request/response pair, so it can be saved as a HAR fixture
+// with Save once the test interaction is done.
+type Recorder struct {
+	target *url.URL
+	proxy  *httputil.ReverseProxy
+	rec    Recording
+}
+
+// NewRecorder creates a Recorder proxying to target.
+func NewRecorder(target string) (*Recorder, error) {
+	u, err := url.Parse(target)
+	if err != nil {
+		return nil, err
+	}
+
+	r := &Recorder{target: u}
+	r.proxy = httputil.NewSingleHostReverseProxy(u)
+	r.proxy.ModifyResponse = r.captureResponse
+
+	return r, nil
+}
+
+func (r *Recorder) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	r.rec.Interactions = append(r.rec.Interactions, Interaction{
+		Request: Request{Method: req.Method, URL: req.URL.Path},
+	})
+	r.proxy.ServeHTTP(w, req)
+}
+
+func (r *Recorder) captureResponse(resp *http.Response) error {
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	_ = resp.Body.Close()
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	headers := make(map[string]string, len(resp.Header))
+	for k := range resp.Header {
+		headers[k] = resp.Header.Get(k)
+	}
+
+	last := &r.rec.Interactions[len(r.rec.Interactions)-1]
+	last.Response = Response{
+		StatusCode: resp.StatusCode,
+		Headers:    headers,
+		Body:       string(body),
+	}
+
+	return nil
+}
+
+// Save writes the interactions captured so far to path as a HAR fixture.
+func (r *Recorder) Save(path string) error {
+	return SaveHAR(path, &r.rec)
+}
@@ -0,0 +1,35 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package webtest
+
+import (
+	"net/http"
+)
+
+// Handler returns an http.Handler that replays rec: each incoming request is
+// matched against the recorded requests by method and URL path, in order,
+// and the matching recorded response is served. Unmatched requests get a 404.
+func (rec *Recording) Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		for _, it := range rec.Interactions {
+			if it.Request.Method != "" && it.Request.Method != r.Method {
+				continue
+			}
+			if it.Request.URL != r.URL.Path && it.Request.URL != r.URL.String() {
+				continue
+			}
+
+			for k, v := range it.Response.Headers {
+				w.Header().Set(k, v)
+			}
+			if it.Response.StatusCode == 0 {
+				it.Response.StatusCode = http.StatusOK
+			}
+			w.WriteHeader(it.Response.StatusCode)
+			_, _ = w.Write([]byte(it.Response.Body))
+			return
+		}
+
+		w.WriteHeader(http.StatusNotFound)
+	})
+}
@@ -0,0 +1,70 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package webtest
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecording_Handler(t *testing.T) {
+	rec := &Recording{
+		Interactions: []Interaction{
+			{
+				Request:  Request{Method: http.MethodGet, URL: "/stats"},
+				Response: Response{StatusCode: http.StatusOK, Body: "requests = 42\n"},
+			},
+		},
+	}
+
+	srv := httptest.NewServer(rec.Handler())
+	defer srv.Close()
+
+	resp, err := http.Get(srv.URL + "/stats")
+	require.NoError(t, err)
+	defer func() { _ = resp.Body.Close() }()
+
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "requests = 42\n", string(body))
+}
+
+func TestRecording_Handler_unmatched(t *testing.T) {
+	rec := &Recording{}
+
+	srv := httptest.NewServer(rec.Handler())
+	defer srv.Close()
+
+	resp, err := http.Get(srv.URL + "/missing")
+	require.NoError(t, err)
+	defer func() { _ = resp.Body.Close() }()
+
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+}
+
+func TestLoadHAR_SaveHAR_roundtrip(t *testing.T) {
+	dir := t.TempDir()
+	path := dir + "/fixture.har.json"
+
+	rec := &Recording{
+		Interactions: []Interaction{
+			{
+				Request:  Request{Method: http.MethodGet, URL: "/x"},
+				Response: Response{StatusCode: http.StatusOK, Body: "ok"},
+			},
+		},
+	}
+
+	require.NoError(t, SaveHAR(path, rec))
+
+	got, err := LoadHAR(path)
+	require.NoError(t, err)
+	assert.Equal(t, rec, got)
+}